@@ -0,0 +1,100 @@
+package trezor
+
+import (
+	termbox "github.com/sml/termbox-go"
+)
+
+// GetPassphrase implements Trezor passphrase entry with a full-screen
+// terminal-based UI.
+//
+// Trezor devices with passphrase protection enabled require an additional
+// secret after the PIN before the wallet is usable. Unlike the PIN, the
+// passphrase is typed directly rather than through a scrambled keypad, so
+// this function lets the user enter arbitrary UTF-8 text, masked on screen.
+func GetPassphrase(prompt string) (string, error) {
+	err := termbox.Init()
+	if err != nil {
+		return "", err
+	}
+	defer termbox.Close()
+
+	termbox.SetInputMode(termbox.InputEsc | termbox.InputAlt)
+
+	var (
+		runes  []rune
+		cursor int // Index into runes where the next typed rune is inserted.
+	)
+
+	insert := func(rs []rune) {
+		runes = append(runes[:cursor:cursor], append(append([]rune{}, rs...), runes[cursor:]...)...)
+		cursor += len(rs)
+	}
+
+	for {
+		// Render.
+		{
+			termbox.Clear(termbox.ColorDefault, termbox.ColorDefault)
+
+			width, _ := termbox.Size()
+			promptLines := wrapPrompt(prompt, width)
+			for i, line := range promptLines {
+				printStr(0, i, line)
+			}
+			y := len(promptLines) + 1
+
+			mask := make([]rune, len(runes))
+			for i := range mask {
+				mask[i] = '●'
+			}
+			printStr(0, y, string(mask))
+
+			printStr(0, y+3, "[Arrow keys]:       move cursor")
+			printStr(0, y+4, "[Backspace/Delete]: delete")
+			printStr(0, y+5, "[Enter]:            submit passphrase")
+			printStr(0, y+6, "[Esc]:              exit without submitting passphrase")
+
+			termbox.SetCursor(cursor, y)
+			termbox.Flush()
+		}
+
+		// Update state.
+		{
+			event := termbox.PollEvent()
+
+			switch event.Type {
+			case termbox.EventKey:
+				if event.Key == termbox.KeyCtrlC || event.Key == termbox.KeyEsc {
+					return "", ErrUserCancelledInput
+				}
+
+				switch event.Key {
+				case termbox.KeyArrowLeft:
+					if cursor > 0 {
+						cursor--
+					}
+				case termbox.KeyArrowRight:
+					if cursor < len(runes) {
+						cursor++
+					}
+				case termbox.KeyBackspace, termbox.KeyBackspace2:
+					if cursor > 0 {
+						runes = append(runes[:cursor-1], runes[cursor:]...)
+						cursor--
+					}
+				case termbox.KeyDelete:
+					if cursor < len(runes) {
+						runes = append(runes[:cursor], runes[cursor+1:]...)
+					}
+				case termbox.KeyEnter:
+					return string(runes), nil
+				case termbox.KeySpace:
+					insert([]rune{' '})
+				default:
+					if event.Ch != 0 {
+						insert([]rune{event.Ch})
+					}
+				}
+			}
+		}
+	}
+}
@@ -11,112 +11,49 @@
 // This is easier to understand if you have gone through the process yourself. You can
 // do so by using a physical Trezor and the official Trezor wallet software.
 //
-// This library implements a full-screen terminal-based UI for PIN entry.
-
+// Trezor devices with passphrase protection enabled also require a passphrase after
+// the PIN; GetPassphrase provides the equivalent UI for that step, and Unlock ties the
+// two together for callers that drive both prompts from a single Open call.
+//
+// This library implements a full-screen terminal-based UI for PIN entry. PIN entry is
+// pluggable: see PINEntry.
 package trezor
 
-import (
-	"errors"
-	"strings"
-
-	termbox "github.com/sml/termbox-go"
-)
+import "errors"
 
 var ErrUserCancelledInput = errors.New("user cancelled PIN entry")
 
-// GetPIN implements Trezor PIN entry with a full-screen terminal-based UI.
-func GetPIN(prompt string) (string, error) {
-	err := termbox.Init()
-	if err != nil {
-		return "", err
-	}
-	defer termbox.Close()
-
-	var (
-		cursorX = 1
-		cursorY = 1
-		pin     string
-	)
-
-	clamp := func(x, min, max int) int {
-		if x < min {
-			return min
-		}
-		if x > max {
-			return max
-		}
-		return x
-	}
-
-	printStr := func(x, y int, s string) {
-		i := 0 // Rune index. (Not using the index from `range` because it's a byte index)
-		for _, r := range s {
-			termbox.SetCell(x+i, y, r, termbox.ColorDefault, termbox.ColorDefault)
-			i++
-		}
-	}
-
-	var keypad = [][]string{
-		[]string{"7", "8", "9"},
-		[]string{"4", "5", "6"},
-		[]string{"1", "2", "3"},
-	}
-
-	for {
-		// Render.
-		{
-			termbox.Clear(termbox.ColorDefault, termbox.ColorDefault)
-
-			printStr(0, 0, prompt)
-
-			printStr(3, 2, "●●●")
-			printStr(3, 3, "●●●")
-			printStr(3, 4, "●●●")
-
-			printStr(0, 6, "PIN: "+strings.Map(func(rune) rune { return '●' }, pin))
-
-			printStr(0, 9, "[Arrow keys]: move cursor")
-			printStr(0, 10, "[Space]:      press button under cursor")
-			printStr(0, 11, "[Backspace]:  delete")
-			printStr(0, 12, "[Enter]:      submit PIN")
-			printStr(0, 13, "[q]:          exit without submitting PIN")
-
-			termbox.SetCursor(cursorX+3, cursorY+2)
-			termbox.Flush()
-		}
+// isInitError reports whether err is (or wraps) a termbox initialization
+// failure, as opposed to an error surfaced once the termbox UI is already
+// running, such as ErrUserCancelledInput.
+func isInitError(err error) bool {
+	var initErr *initError
+	return errors.As(err, &initErr)
+}
 
-		// Update state.
-		{
-			event := termbox.PollEvent()
-			if event.Type != termbox.EventKey {
-				continue
-			}
+// PINEntry is implemented by the PIN entry backends this package provides.
+type PINEntry interface {
+	GetPIN(prompt string) (string, error)
+}
 
-			if event.Ch == 'q' || event.Key == termbox.KeyCtrlC {
-				return "", ErrUserCancelledInput
-			}
+// Default is the PINEntry backend used by GetPIN. It defaults to the
+// full-screen termbox UI.
+var Default PINEntry = termboxPINEntry{}
 
-			switch event.Key {
-			case termbox.KeyArrowUp:
-				cursorY--
-			case termbox.KeyArrowDown:
-				cursorY++
-			case termbox.KeyArrowLeft:
-				cursorX--
-			case termbox.KeyArrowRight:
-				cursorX++
-			case termbox.KeySpace:
-				pin += keypad[cursorY][cursorX]
-			case termbox.KeyBackspace, termbox.KeyBackspace2:
-				if len(pin) > 0 {
-					pin = pin[:len(pin)-1]
-				}
-			case termbox.KeyEnter:
-				return pin, nil
-			}
+// fallback is the PINEntry backend used when the termbox full-screen UI
+// cannot initialize.
+var fallback PINEntry = readlinePINEntry{}
 
-			cursorY = clamp(cursorY, 0, 2)
-			cursorX = clamp(cursorX, 0, 2)
-		}
+// GetPIN implements Trezor PIN entry using Default, falling back to a
+// readline-based backend when the termbox full-screen UI cannot initialize
+// (typically because stdin/stdout isn't a suitable TTY, eg over some SSH
+// sessions or when output is redirected). Errors encountered after the
+// termbox UI has started, such as ErrUserCancelledInput, are returned as-is
+// rather than triggering the fallback.
+func GetPIN(prompt string) (string, error) {
+	pin, err := Default.GetPIN(prompt)
+	if isInitError(err) {
+		return fallback.GetPIN(prompt)
 	}
+	return pin, err
 }
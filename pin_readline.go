@@ -0,0 +1,54 @@
+package trezor
+
+import (
+	"fmt"
+	"os"
+
+	"golang.org/x/term"
+)
+
+// readlinePINEntry implements PINEntry without taking over the whole
+// screen, so it works over SSH sessions and dumb terminals where termbox
+// cannot initialize.
+//
+// Rather than drawing a blank keypad the user navigates with arrow keys, it
+// asks the user to type the letters overlaid on a reference keypad, mirroring
+// the scrambled digits shown on the Trezor screen. This is the same scheme
+// used by the go-ethereum console bridge.
+type readlinePINEntry struct{}
+
+// pinLetterDigit maps the letters shown by the reference keypad to the
+// digit at that position.
+var pinLetterDigit = map[rune]byte{
+	'a': '7', 'b': '8', 'c': '9',
+	'd': '4', 'e': '5', 'f': '6',
+	'g': '1', 'h': '2', 'i': '3',
+}
+
+func (readlinePINEntry) GetPIN(prompt string) (string, error) {
+	fmt.Println(prompt)
+	fmt.Println("Look at the scrambled PIN matrix on your Trezor and type the letters in the")
+	fmt.Println("same positions, then press Enter:")
+	fmt.Println("  a b c")
+	fmt.Println("  d e f")
+	fmt.Println("  g h i")
+
+	raw, err := term.ReadPassword(int(os.Stdin.Fd()))
+	if err != nil {
+		return "", err
+	}
+
+	var pin []byte
+	for _, r := range string(raw) {
+		digit, ok := pinLetterDigit[r]
+		if !ok {
+			continue
+		}
+		pin = append(pin, digit)
+	}
+	if len(pin) == 0 {
+		return "", ErrUserCancelledInput
+	}
+
+	return string(pin), nil
+}
@@ -0,0 +1,223 @@
+package trezor
+
+import (
+	"context"
+	"strings"
+
+	termbox "github.com/sml/termbox-go"
+)
+
+// termboxPINEntry implements PINEntry with a full-screen terminal-based UI.
+type termboxPINEntry struct{}
+
+func (termboxPINEntry) GetPIN(prompt string) (string, error) {
+	return GetPINWithConfig(prompt, DefaultConfig())
+}
+
+// initError wraps a failure to initialize the termbox screen, distinguishing
+// it from errors returned once the UI is up and running (eg user
+// cancellation), so callers like GetPIN can fall back to another backend
+// only when termbox itself couldn't start.
+type initError struct{ err error }
+
+func (e *initError) Error() string { return e.err.Error() }
+func (e *initError) Unwrap() error { return e.err }
+
+// Config controls the keybindings and input modes of the termbox PIN entry
+// UI used by GetPINWithConfig.
+type Config struct {
+	// MoveUp, MoveDown, MoveLeft, MoveRight are extra runes, beyond the
+	// arrow keys (which are always active), that move the cursor.
+	MoveUp, MoveDown, MoveLeft, MoveRight []rune
+
+	// Submit, Cancel, Press are extra runes, beyond Enter, 'q'/Ctrl-C, and
+	// Space respectively, that submit the PIN, cancel entry, or press the
+	// keypad cell under the cursor.
+	Submit, Cancel, Press []rune
+
+	// Mouse enables clicking a keypad cell to press it.
+	Mouse bool
+}
+
+// DefaultConfig is the Config used by GetPIN: hjkl and WASD move the cursor
+// in addition to the arrow keys, and mouse input is disabled. This suits
+// terminals (eg inside tmux or screen) where arrow keys may be intercepted.
+func DefaultConfig() Config {
+	return Config{
+		MoveUp:    []rune{'k', 'w'},
+		MoveDown:  []rune{'j', 's'},
+		MoveLeft:  []rune{'h', 'a'},
+		MoveRight: []rune{'l', 'd'},
+	}
+}
+
+func runeIn(rs []rune, r rune) bool {
+	for _, x := range rs {
+		if x == r {
+			return true
+		}
+	}
+	return false
+}
+
+// GetPINWithConfig implements Trezor PIN entry with a full-screen
+// terminal-based UI, using cfg for keybindings and input modes. Like GetPIN,
+// it falls back to the readline backend when termbox cannot initialize; the
+// readline backend has no keybindings of its own, so cfg has no effect on
+// the fallback.
+func GetPINWithConfig(prompt string, cfg Config) (string, error) {
+	pin, err := getPIN(context.Background(), prompt, cfg)
+	if isInitError(err) {
+		return fallback.GetPIN(prompt)
+	}
+	return pin, err
+}
+
+// GetPINWithContext implements Trezor PIN entry with a full-screen
+// terminal-based UI that aborts with ctx.Err() if ctx is done before the
+// user submits or cancels. This lets callers enforce a Trezor's auto-lock
+// window (typically 15s) or thread a context down from a hardware-wallet
+// driver's Open call. Like GetPIN, it falls back to the readline backend
+// when termbox cannot initialize; the readline backend does not watch ctx,
+// so once the fallback starts it can no longer be aborted that way.
+func GetPINWithContext(ctx context.Context, prompt string) (string, error) {
+	pin, err := getPIN(ctx, prompt, DefaultConfig())
+	if isInitError(err) {
+		return fallback.GetPIN(prompt)
+	}
+	return pin, err
+}
+
+func getPIN(ctx context.Context, prompt string, cfg Config) (string, error) {
+	if err := termbox.Init(); err != nil {
+		return "", &initError{err}
+	}
+	defer termbox.Close()
+
+	if cfg.Mouse {
+		termbox.SetInputMode(termbox.InputEsc | termbox.InputMouse)
+	}
+
+	// Watch ctx in the background and interrupt the blocking poll below
+	// once it's done, so the entry loop notices promptly rather than
+	// waiting for the next keypress.
+	watchDone := make(chan struct{})
+	defer close(watchDone)
+	go func() {
+		select {
+		case <-ctx.Done():
+			termbox.Interrupt()
+		case <-watchDone:
+		}
+	}()
+
+	var (
+		cursorX = 1
+		cursorY = 1
+		pin     string
+	)
+
+	clamp := func(x, min, max int) int {
+		if x < min {
+			return min
+		}
+		if x > max {
+			return max
+		}
+		return x
+	}
+
+	var keypad = [][]string{
+		[]string{"7", "8", "9"},
+		[]string{"4", "5", "6"},
+		[]string{"1", "2", "3"},
+	}
+
+	var keypadX, keypadY int // Top-left cell of the keypad, set each render.
+
+	rawBuf := make([]byte, 64)
+
+	for {
+		// Render.
+		{
+			termbox.Clear(termbox.ColorDefault, termbox.ColorDefault)
+
+			width, _ := termbox.Size()
+			promptLines := wrapPrompt(prompt, width)
+			for i, line := range promptLines {
+				printStr(0, i, line)
+			}
+			y := len(promptLines) + 1
+			keypadX, keypadY = 3, y
+
+			printStr(3, y, "●●●")
+			printStr(3, y+1, "●●●")
+			printStr(3, y+2, "●●●")
+
+			printStr(0, y+4, "PIN: "+strings.Map(func(rune) rune { return '●' }, pin))
+
+			printStr(0, y+7, "[Arrow keys]: move cursor")
+			printStr(0, y+8, "[Space]:      press button under cursor")
+			printStr(0, y+9, "[Backspace]:  delete")
+			printStr(0, y+10, "[Enter]:      submit PIN")
+			printStr(0, y+11, "[q]:          exit without submitting PIN")
+
+			termbox.SetCursor(cursorX+3, cursorY+y)
+			termbox.Flush()
+		}
+
+		// Update state.
+		{
+			rawEvent := termbox.PollRawEvent(rawBuf)
+			if rawEvent.Type == termbox.EventInterrupt {
+				if err := ctx.Err(); err != nil {
+					return "", err
+				}
+				continue
+			}
+
+			event := termbox.ParseEvent(rawBuf[:rawEvent.N])
+
+			if event.Type == termbox.EventMouse {
+				if cfg.Mouse && event.Key == termbox.MouseLeft {
+					px, py := event.MouseX-keypadX, event.MouseY-keypadY
+					if px >= 0 && px < 3 && py >= 0 && py < 3 {
+						cursorX, cursorY = px, py
+						pin += keypad[cursorY][cursorX]
+					}
+				}
+				continue
+			}
+
+			if event.Type != termbox.EventKey {
+				continue
+			}
+
+			if event.Key == termbox.KeyCtrlC || runeIn(cfg.Cancel, event.Ch) || event.Ch == 'q' {
+				return "", ErrUserCancelledInput
+			}
+
+			switch {
+			case event.Key == termbox.KeyArrowUp || runeIn(cfg.MoveUp, event.Ch):
+				cursorY--
+			case event.Key == termbox.KeyArrowDown || runeIn(cfg.MoveDown, event.Ch):
+				cursorY++
+			case event.Key == termbox.KeyArrowLeft || runeIn(cfg.MoveLeft, event.Ch):
+				cursorX--
+			case event.Key == termbox.KeyArrowRight || runeIn(cfg.MoveRight, event.Ch):
+				cursorX++
+			case event.Key == termbox.KeySpace || runeIn(cfg.Press, event.Ch):
+				pin += keypad[cursorY][cursorX]
+			case event.Key == termbox.KeyBackspace || event.Key == termbox.KeyBackspace2:
+				if len(pin) > 0 {
+					pin = pin[:len(pin)-1]
+				}
+			case event.Key == termbox.KeyEnter || runeIn(cfg.Submit, event.Ch):
+				return pin, nil
+			}
+
+			cursorY = clamp(cursorY, 0, 2)
+			cursorX = clamp(cursorX, 0, 2)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package trezor
+
+import (
+	"errors"
+	"testing"
+)
+
+// fakePINEntry is a PINEntry stub for exercising GetPIN's fallback decision
+// without touching termbox.
+type fakePINEntry struct {
+	pin string
+	err error
+}
+
+func (f fakePINEntry) GetPIN(prompt string) (string, error) { return f.pin, f.err }
+
+func TestGetPINFallback(t *testing.T) {
+	origDefault, origFallback := Default, fallback
+	defer func() { Default, fallback = origDefault, origFallback }()
+
+	t.Run("init failure falls back", func(t *testing.T) {
+		Default = fakePINEntry{err: &initError{errors.New("no tty")}}
+		fallback = fakePINEntry{pin: "1234"}
+		if pin, err := GetPIN("PIN: "); pin != "1234" || err != nil {
+			t.Fatalf("GetPIN: want (%q, nil) from the fallback backend, got (%q, %v)", "1234", pin, err)
+		}
+	})
+
+	t.Run("cancellation is not a fallback trigger", func(t *testing.T) {
+		Default = fakePINEntry{err: ErrUserCancelledInput}
+		fallback = fakePINEntry{pin: "1234"}
+		_, err := GetPIN("PIN: ")
+		if !errors.Is(err, ErrUserCancelledInput) {
+			t.Fatalf("GetPIN: want ErrUserCancelledInput propagated as-is, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,57 @@
+package trezor
+
+import (
+	runewidth "github.com/mattn/go-runewidth"
+	termbox "github.com/sml/termbox-go"
+)
+
+// printStr draws s starting at cell (x, y), advancing the column by the
+// on-screen cell width of each rune rather than by rune count. This keeps
+// CJK/emoji prompts and combining marks laid out correctly: zero-width runes
+// (eg combining marks) are skipped, so they claim no cell of their own and are
+// not drawn, and the second column of a wide rune is cleared so it isn't left
+// showing a stale glyph from a previous frame.
+func printStr(x, y int, s string) {
+	col := x
+	for _, r := range s {
+		w := runewidth.RuneWidth(r)
+		if w == 0 {
+			continue
+		}
+
+		termbox.SetCell(col, y, r, termbox.ColorDefault, termbox.ColorDefault)
+		if w == 2 {
+			termbox.SetCell(col+1, y, 0, termbox.ColorDefault, termbox.ColorDefault)
+		}
+		col += w
+	}
+}
+
+// wrapPrompt splits s into lines of at most width terminal cells, breaking
+// between runes rather than mid-wide-rune, so a long prompt doesn't run off
+// the edge of a narrow terminal.
+func wrapPrompt(s string, width int) []string {
+	if width <= 0 {
+		return []string{s}
+	}
+
+	var (
+		lines []string
+		line  []rune
+		col   int
+	)
+
+	for _, r := range s {
+		w := runewidth.RuneWidth(r)
+		if col+w > width && len(line) > 0 {
+			lines = append(lines, string(line))
+			line = nil
+			col = 0
+		}
+		line = append(line, r)
+		col += w
+	}
+	lines = append(lines, string(line))
+
+	return lines
+}
@@ -0,0 +1,84 @@
+package trezor
+
+import (
+	"testing"
+
+	termbox "github.com/sml/termbox-go"
+)
+
+func TestPrintStrCellPlacement(t *testing.T) {
+	if err := termbox.Init(); err != nil {
+		t.Skipf("termbox.Init: %v (no usable terminal)", err)
+	}
+	defer termbox.Close()
+
+	w, _ := termbox.Size()
+	if w == 0 {
+		t.Skip("terminal reports zero width")
+	}
+
+	cases := []struct {
+		name  string
+		s     string
+		width int
+	}{
+		{"ascii", "abc", 3},
+		{"wide", "日本語", 6},                 // each CJK rune occupies two cells
+		{"combining", "ábc", 3}, // 'a' + combining acute accent (U+0301) adds no width
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			termbox.Clear(termbox.ColorDefault, termbox.ColorDefault)
+			printStr(0, 0, c.s)
+
+			buf := termbox.CellBuffer()
+			// termbox.Clear fills every cell with ' ', so a cell printStr
+			// didn't write still holds a space.
+			if ch := buf[c.width].Ch; ch != ' ' {
+				t.Errorf("cell at column %d = %q, want blank ' ' (string should occupy exactly %d cells)", c.width, ch, c.width)
+			}
+		})
+	}
+
+	t.Run("combining mark doesn't claim its own cell", func(t *testing.T) {
+		termbox.Clear(termbox.ColorDefault, termbox.ColorDefault)
+		printStr(0, 0, "ábc")
+
+		buf := termbox.CellBuffer()
+		if ch := buf[1].Ch; ch != 'b' {
+			t.Errorf("cell at column 1 = %q, want 'b' (combining mark must not advance col or occupy its own cell)", ch)
+		}
+	})
+}
+
+func TestWrapPrompt(t *testing.T) {
+	cases := []struct {
+		s     string
+		width int
+		lines []string
+	}{
+		{"hello world", 5, []string{"hello", " worl", "d"}},
+		{"日本語", 4, []string{"日本", "語"}},
+		{"short", 80, []string{"short"}},
+		{"ábc def", 3, []string{"ábc", " de", "f"}}, // combining mark doesn't count toward line width
+	}
+
+	for _, c := range cases {
+		if got := wrapPrompt(c.s, c.width); !equalStrings(got, c.lines) {
+			t.Errorf("wrapPrompt(%q, %d) = %#v, want %#v", c.s, c.width, got, c.lines)
+		}
+	}
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
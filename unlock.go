@@ -0,0 +1,60 @@
+package trezor
+
+import "errors"
+
+// ErrTrezorPINNeeded is returned by a Wallet's Open method when the device
+// must be unlocked with a PIN before it can proceed.
+var ErrTrezorPINNeeded = errors.New("trezor: PIN needed")
+
+// ErrTrezorPassphraseNeeded is returned by a Wallet's Open method when the
+// device requires a passphrase in addition to the PIN before it can
+// proceed.
+var ErrTrezorPassphraseNeeded = errors.New("trezor: passphrase needed")
+
+// Wallet is implemented by the caller's hardware-wallet driver. Open
+// attempts to unlock the device using the given PIN and passphrase (either
+// may be empty if not yet collected) and should return ErrTrezorPINNeeded or
+// ErrTrezorPassphraseNeeded if the device needs that secret before it can
+// proceed.
+type Wallet interface {
+	Open(pin, passphrase string) error
+}
+
+// Credentials holds the secrets collected from the user while unlocking a
+// Trezor.
+type Credentials struct {
+	PIN        string
+	Passphrase string
+}
+
+// Unlock drives w through the PIN and passphrase entry UIs, feeding back
+// whatever secret the device asks for, until Open succeeds or fails with an
+// error other than ErrTrezorPINNeeded / ErrTrezorPassphraseNeeded.
+func Unlock(w Wallet) (*Credentials, error) {
+	var creds Credentials
+
+	for {
+		err := w.Open(creds.PIN, creds.Passphrase)
+		switch err {
+		case nil:
+			return &creds, nil
+
+		case ErrTrezorPINNeeded:
+			pin, err := GetPIN("Enter PIN:")
+			if err != nil {
+				return nil, err
+			}
+			creds.PIN = pin
+
+		case ErrTrezorPassphraseNeeded:
+			passphrase, err := GetPassphrase("Enter passphrase:")
+			if err != nil {
+				return nil, err
+			}
+			creds.Passphrase = passphrase
+
+		default:
+			return nil, err
+		}
+	}
+}